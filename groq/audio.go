@@ -0,0 +1,215 @@
+package groq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// AudioRequest describes a request to Groq's Whisper-compatible audio
+// transcription/translation endpoints. Either FilePath or Reader+Filename
+// must be set; if both are set, Reader takes precedence.
+type AudioRequest struct {
+	FilePath string    // Path to the audio file on disk
+	Reader   io.Reader // Audio data to upload; if set, Filename must also be set
+	Filename string    // Filename reported to the API when Reader is used
+
+	// Reader is read once per attempt, so if the request is retried (see
+	// client.wait_on_ratelimit), Reader must implement io.Seeker so it can
+	// be rewound; a non-seekable Reader causes the retry to fail with an
+	// explicit error rather than silently uploading a partial/empty file.
+	// FilePath-based requests don't have this restriction: the file is
+	// reopened on every attempt.
+
+	Model ModelID // ID of the model to use, e.g. "whisper-large-v3"
+
+	Prompt      string  // Optional text to guide the model's style or continue a previous audio segment
+	Language    string  // ISO-639-1 language code of the input audio (transcription only)
+	Temperature float64 // Sampling temperature
+
+	// ResponseFormat is one of "json" (default), "text", "srt",
+	// "verbose_json", or "vtt".
+	ResponseFormat string
+}
+
+// AudioResponse is the result of a transcription or translation request. Text
+// always holds the transcript, regardless of ResponseFormat: for "text",
+// "srt", and "vtt" it holds the raw response body, and for "json"/
+// "verbose_json" it holds the decoded "text" field.
+type AudioResponse struct {
+	Text     string          `json:"text"`
+	Language string          `json:"language,omitempty"`
+	Duration float64         `json:"duration,omitempty"`
+	Segments json.RawMessage `json:"segments,omitempty"`
+}
+
+func isRawTextResponseFormat(format string) bool {
+	switch format {
+	case "text", "srt", "vtt":
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateTranscription transcribes audio into the input language.
+//
+// Deprecated: prefer CreateTranscriptionWithContext.
+func (c *client) CreateTranscription(req AudioRequest) (*AudioResponse, error) {
+	return c.CreateTranscriptionWithContext(context.Background(), req)
+}
+
+// CreateTranscriptionWithContext transcribes audio into the input language,
+// honoring ctx for cancellation of both the upload and any retry wait.
+func (c *client) CreateTranscriptionWithContext(ctx context.Context, req AudioRequest) (*AudioResponse, error) {
+	return c.createAudio(ctx, "/v1/audio/transcriptions", req)
+}
+
+// CreateTranslation translates audio into English.
+//
+// Deprecated: prefer CreateTranslationWithContext.
+func (c *client) CreateTranslation(req AudioRequest) (*AudioResponse, error) {
+	return c.CreateTranslationWithContext(context.Background(), req)
+}
+
+// CreateTranslationWithContext translates audio into English, honoring ctx
+// for cancellation of both the upload and any retry wait.
+func (c *client) CreateTranslationWithContext(ctx context.Context, req AudioRequest) (*AudioResponse, error) {
+	return c.createAudio(ctx, "/v1/audio/translations", req)
+}
+
+func (c *client) createAudio(ctx context.Context, path string, req AudioRequest) (*AudioResponse, error) {
+	resp, body, err := c.doWithRetry(ctx, func(attempt int) (*http.Response, []byte, error) {
+		return c.makeAudioReq(ctx, path, req, attempt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status code: %d, body: %s", resp.StatusCode, body)
+	}
+	return parseAudioResponse(req.ResponseFormat, body)
+}
+
+// makeAudioReq builds a multipart/form-data body from req and sends it to
+// path. The file is streamed through an io.Pipe so it is never buffered in
+// memory. attempt is the retry attempt number (0 on the first try); for
+// Reader-sourced requests it's used to rewind the Reader before a retry.
+func (c *client) makeAudioReq(ctx context.Context, path string, req AudioRequest, attempt int) (*http.Response, []byte, error) {
+	file, filename, err := openAudioSource(req, attempt)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		err := writeAudioForm(mw, file, filename, req)
+		_ = pw.CloseWithError(err)
+	}()
+
+	url := fmt.Sprintf("%s%s", c.baseURL, path)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read response body")
+	}
+	return resp, body, nil
+}
+
+func openAudioSource(req AudioRequest, attempt int) (io.ReadCloser, string, error) {
+	if req.Reader != nil {
+		if req.Filename == "" {
+			return nil, "", fmt.Errorf("Filename must be set when Reader is used")
+		}
+		if attempt > 0 {
+			seeker, ok := req.Reader.(io.Seeker)
+			if !ok {
+				return nil, "", fmt.Errorf("cannot retry audio request: Reader does not implement io.Seeker")
+			}
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, "", errors.Wrap(err, "failed to rewind Reader for retry")
+			}
+		}
+		// The caller owns Reader: it is not closed here, so a seekable one
+		// (e.g. an *os.File) can still be rewound on the next attempt.
+		return io.NopCloser(req.Reader), req.Filename, nil
+	}
+
+	if req.FilePath == "" {
+		return nil, "", fmt.Errorf("one of FilePath or Reader must be set")
+	}
+
+	file, err := os.Open(req.FilePath)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to open audio file")
+	}
+	return file, filepath.Base(req.FilePath), nil
+}
+
+func writeAudioForm(mw *multipart.Writer, file io.Reader, filename string, req AudioRequest) error {
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %v", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return errors.Wrap(err, "failed to stream audio file")
+	}
+
+	fields := map[string]string{
+		"model":           string(req.Model),
+		"prompt":          req.Prompt,
+		"language":        req.Language,
+		"response_format": req.ResponseFormat,
+	}
+	for field, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := mw.WriteField(field, value); err != nil {
+			return fmt.Errorf("failed to write field %q: %v", field, err)
+		}
+	}
+	if req.Temperature != 0 {
+		if err := mw.WriteField("temperature", fmt.Sprintf("%v", req.Temperature)); err != nil {
+			return fmt.Errorf("failed to write field %q: %v", "temperature", err)
+		}
+	}
+
+	return mw.Close()
+}
+
+func parseAudioResponse(responseFormat string, body []byte) (*AudioResponse, error) {
+	if isRawTextResponseFormat(responseFormat) {
+		return &AudioResponse{Text: string(body)}, nil
+	}
+
+	var audioResp AudioResponse
+	if err := json.Unmarshal(body, &audioResp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response")
+	}
+	return &audioResp, nil
+}
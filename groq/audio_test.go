@@ -0,0 +1,66 @@
+package groq
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenAudioSourceFilePathUsesBasename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "call.wav")
+	if err := os.WriteFile(path, []byte("audio"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rc, filename, err := openAudioSource(AudioRequest{FilePath: path}, 0)
+	if err != nil {
+		t.Fatalf("openAudioSource() error = %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	if filename != "call.wav" {
+		t.Fatalf("filename = %q, want %q", filename, "call.wav")
+	}
+}
+
+func TestOpenAudioSourceReaderRetryWithoutSeekerFails(t *testing.T) {
+	req := AudioRequest{Reader: bytes.NewReader([]byte("audio")), Filename: "call.wav"}
+
+	if _, _, err := openAudioSource(req, 0); err != nil {
+		t.Fatalf("first attempt: openAudioSource() error = %v", err)
+	}
+
+	// io.NopCloser around a plain io.Reader (not a Seeker) must refuse retry
+	// rather than silently uploading a drained, empty body.
+	req.Reader = io.NopCloser(bytes.NewReader([]byte("audio")))
+	if _, _, err := openAudioSource(req, 1); err == nil {
+		t.Fatal("retry of non-seekable Reader: openAudioSource() error = nil, want error")
+	}
+}
+
+func TestOpenAudioSourceReaderRetryRewindsSeekableReader(t *testing.T) {
+	req := AudioRequest{Reader: bytes.NewReader([]byte("audio")), Filename: "call.wav"}
+
+	rc, _, err := openAudioSource(req, 0)
+	if err != nil {
+		t.Fatalf("first attempt: openAudioSource() error = %v", err)
+	}
+	if _, err := io.ReadAll(rc); err != nil {
+		t.Fatalf("drain first attempt: %v", err)
+	}
+
+	rc, _, err = openAudioSource(req, 1)
+	if err != nil {
+		t.Fatalf("retry attempt: openAudioSource() error = %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("drain retry attempt: %v", err)
+	}
+	if string(got) != "audio" {
+		t.Fatalf("retry attempt read %q, want %q (Reader should have been rewound)", got, "audio")
+	}
+}
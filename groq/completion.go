@@ -20,9 +20,16 @@ const (
 
 type Client interface {
 	CreateChatCompletion(ChatCompletionRequest) (*ChatCompletionResponse, error)
-	CreateChatCompletionStream(context.Context, ChatCompletionRequest) (<-chan *ChatCompletionStreamResponse, func(), error)
+	CreateChatCompletionWithContext(context.Context, ChatCompletionRequest) (*ChatCompletionResponse, error)
+	CreateChatCompletionStream(context.Context, ChatCompletionRequest) (<-chan *ChatCompletionStreamResponse, <-chan error, func(), error)
 	ListModels() (*ListModelsResponse, error)
+	ListModelsWithContext(context.Context) (*ListModelsResponse, error)
 	RetrieveModel(ModelID) (*Model, error)
+	RetrieveModelWithContext(context.Context, ModelID) (*Model, error)
+	CreateTranscription(AudioRequest) (*AudioResponse, error)
+	CreateTranscriptionWithContext(context.Context, AudioRequest) (*AudioResponse, error)
+	CreateTranslation(AudioRequest) (*AudioResponse, error)
+	CreateTranslationWithContext(context.Context, AudioRequest) (*AudioResponse, error)
 }
 
 var _ Client = (*client)(nil)
@@ -34,25 +41,53 @@ type client struct {
 	client                      *http.Client
 	max_wait_on_ratelimit_in_ms int
 	wait_on_ratelimit           bool
+	retryPolicy                 RetryPolicy
+	logger                      Logger
+	middlewares                 []RequestMiddleware
+	hooks                       Hooks
+}
+
+// ClientOption configures optional behavior on the client returned by
+// NewClient.
+type ClientOption func(*client)
+
+// WithRetryPolicy overrides the RetryPolicy used to decide whether and how
+// long to wait between retried requests. The default is NewDefaultRetryPolicy
+// with 5 retries and the max_wait_on_ratelimit_in_ms argument passed to
+// NewClient as its ceiling. Either way, requests are retried only when
+// NewClient's wait_on_ratelimit argument is true.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithLogger overrides the Logger used to report retry and rate-limit
+// activity. The default Logger discards everything.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *client) {
+		c.logger = logger
+	}
 }
 
 // ChatCompletionRequest represents the request body for creating a chat completion.
 type ChatCompletionRequest struct {
-	Messages         []Message   `json:"messages"`                    // A list of messages comprising the conversation so far.
-	Model            ModelID     `json:"model"`                       // ID of the model to use
-	MaxTokens        int         `json:"max_tokens,omitempty"`        // The maximum number of tokens that can be generated in the chat completion. The total length of input tokens and generated tokens is limited by the model's context length.
-	Temperature      float64     `json:"temperature,omitempty"`       // Sampling temperature
-	TopP             float64     `json:"top_p,omitempty"`             // Nucleus sampling probability
-	NumChoices       int         `json:"n,omitempty"`                 // Number of completion choices to generate
-	PresencePenalty  float64     `json:"presence_penalty,omitempty"`  // Penalty for presence of tokens
-	FrequencyPenalty *float64    `json:"frequency_penalty,omitempty"` // Number between -2.0 and 2.0. Positive values penalize new tokens based on their existing frequency in the text so far, decreasing the model's likelihood to repeat the same line verbatim.
-	UserID           string      `json:"user,omitempty"`              // Unique identifier for the end-user
-	Stream           bool        `json:"stream,omitempty"`            // If set, partial message deltas will be sent as data-only server-sent events
-	ToolChoice       interface{} `json:"tool_choice,omitempty"`       // Controls which tool is called by the model
-	Tools            interface{} `json:"tools,omitempty"`             // List of tools the model may call
-	FunctionCall     interface{} `json:"function_call,omitempty"`     // Controls which function is called by the model
-	ResponseFormat   interface{} `json:"response_format,omitempty"`   // Format of the model's response
-	Seed             int         `json:"seed,omitempty"`              // Seed for deterministic sampling
+	Messages         []Message           `json:"messages"`                    // A list of messages comprising the conversation so far.
+	Model            ModelID             `json:"model"`                       // ID of the model to use
+	MaxTokens        int                 `json:"max_tokens,omitempty"`        // The maximum number of tokens that can be generated in the chat completion. The total length of input tokens and generated tokens is limited by the model's context length.
+	Temperature      float64             `json:"temperature,omitempty"`       // Sampling temperature
+	TopP             float64             `json:"top_p,omitempty"`             // Nucleus sampling probability
+	NumChoices       int                 `json:"n,omitempty"`                 // Number of completion choices to generate
+	PresencePenalty  float64             `json:"presence_penalty,omitempty"`  // Penalty for presence of tokens
+	FrequencyPenalty *float64            `json:"frequency_penalty,omitempty"` // Number between -2.0 and 2.0. Positive values penalize new tokens based on their existing frequency in the text so far, decreasing the model's likelihood to repeat the same line verbatim.
+	UserID           string              `json:"user,omitempty"`              // Unique identifier for the end-user
+	Stream           bool                `json:"stream,omitempty"`            // If set, partial message deltas will be sent as data-only server-sent events
+	StreamOptions    *StreamOptions      `json:"stream_options,omitempty"`    // Options for streaming responses; only set when Stream is true
+	ToolChoice       *ToolChoice         `json:"tool_choice,omitempty"`       // Controls which tool is called by the model
+	Tools            []Tool              `json:"tools,omitempty"`             // List of tools the model may call
+	FunctionCall     *FunctionCallOption `json:"function_call,omitempty"`     // Deprecated: superseded by ToolChoice
+	ResponseFormat   *ResponseFormat     `json:"response_format,omitempty"`   // Format of the model's response
+	Seed             int                 `json:"seed,omitempty"`              // Seed for deterministic sampling
 
 	// StopSequences is a predefined or user-specified text string that
 	// signals an AI to stop generating content, ensuring its responses
@@ -61,6 +96,22 @@ type ChatCompletionRequest struct {
 	StopSequences interface{} `json:"stop,omitempty"`
 }
 
+// Message represents a single message in a chat completion conversation,
+// whether supplied by the caller or returned by the model.
+type Message struct {
+	Role    string `json:"role"`           // "system", "user", "assistant", or "tool"
+	Content string `json:"content"`        // Text content of the message
+	Name    string `json:"name,omitempty"` // Optional name of the participant
+
+	// ToolCalls holds the tool invocations requested by the model on an
+	// assistant message.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+
+	// ToolCallID identifies, on a "tool" role message, which of the
+	// assistant's ToolCalls this message is the result of.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
 // Choice represents a single completion choice returned by the chat completion API.
 type Choice struct {
 	Index        int     `json:"index"`         // Index of the choice
@@ -97,17 +148,52 @@ type ErrorResponse struct {
 	} `json:"error"`
 }
 
-func NewClient(apiKey string, httpClient *http.Client, wait_on_ratelimit bool, max_wait_on_ratelimit_in_ms int) Client {
-	return &client{
+// ModelID identifies a model hosted on Groq, e.g. "llama3-8b-8192".
+type ModelID string
+
+// Model describes a model available through the Groq API.
+type Model struct {
+	ID            ModelID `json:"id"`             // ID of the model
+	Object        string  `json:"object"`         // Type of the object (e.g., "model")
+	Created       int64   `json:"created"`        // Timestamp of creation
+	OwnedBy       string  `json:"owned_by"`        // Organization that owns the model
+	Active        bool    `json:"active"`          // Whether the model is currently active
+	ContextWindow int     `json:"context_window"` // Maximum context window size, in tokens
+}
+
+// ListModelsResponse represents the response from the list models API.
+type ListModelsResponse struct {
+	Object string  `json:"object"` // Type of the object (e.g., "list")
+	Data   []Model `json:"data"`   // List of available models
+}
+
+func NewClient(apiKey string, httpClient *http.Client, wait_on_ratelimit bool, max_wait_on_ratelimit_in_ms int, opts ...ClientOption) Client {
+	c := &client{
 		apiKey: apiKey,
 		client: httpClient,
 		// NOTE(@Kcrong): Need to handle if the user wants to use a different base URL
 		baseURL:                     baseURL,
 		max_wait_on_ratelimit_in_ms: max_wait_on_ratelimit_in_ms,
 		wait_on_ratelimit:           wait_on_ratelimit,
+		retryPolicy:                 NewDefaultRetryPolicy(5, time.Duration(max_wait_on_ratelimit_in_ms)*time.Millisecond),
+		logger:                      noopLogger{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	base := c.client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	wrapped := *c.client
+	wrapped.Transport = c.buildTransport(base)
+	c.client = &wrapped
+
+	return c
 }
-func (c *client) makeReq(req ChatCompletionRequest) (*http.Response, []byte, error) {
+func (c *client) makeReq(ctx context.Context, req ChatCompletionRequest) (*http.Response, []byte, error) {
 	if req.Stream {
 		return nil, nil, fmt.Errorf("use CreateChatCompletionStream for streaming completions")
 	}
@@ -119,7 +205,7 @@ func (c *client) makeReq(req ChatCompletionRequest) (*http.Response, []byte, err
 		return nil, nil, fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create request: %v", err)
 	}
@@ -128,7 +214,7 @@ func (c *client) makeReq(req ChatCompletionRequest) (*http.Response, []byte, err
 	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 	resp, err := c.client.Do(httpReq)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to send request: %v", err)
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer func(Body io.ReadCloser) {
 		_ = Body.Close()
@@ -142,44 +228,26 @@ func (c *client) makeReq(req ChatCompletionRequest) (*http.Response, []byte, err
 }
 
 // CreateChatCompletion sends a request to create a chat completion.
+//
+// Deprecated: prefer CreateChatCompletionWithContext, which allows callers to
+// cancel the request or apply a deadline.
 func (c *client) CreateChatCompletion(req ChatCompletionRequest) (*ChatCompletionResponse, error) {
-	resp, body, err := c.makeReq(req)
+	return c.CreateChatCompletionWithContext(context.Background(), req)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		retry := 0
-		maxRetry := 5
-		for resp.StatusCode == http.StatusTooManyRequests && retry <= maxRetry {
-			retry = retry + 1
-			if retry > maxRetry {
-				return nil, fmt.Errorf("retry is %d max retry :%d, code: %d, body: %s,response : %v", retry, maxRetry, resp.StatusCode, body, resp)
-			}
-			var errResp ErrorResponse
-			err = json.Unmarshal([]byte(body), &errResp)
-			if err != nil {
-				return nil, fmt.Errorf("invalid status code: %d, body: %s, Failed to unmarshall the error body, headers: %v", resp.StatusCode, body, resp)
-			}
-			retrys, err := strconv.Atoi(resp.Header.Get("retry-after"))
-			retryMs := retrys * 1000
-
-			if c.wait_on_ratelimit {
-				fmt.Println("Retry after (ms):", retryMs)
-				if c.max_wait_on_ratelimit_in_ms < retryMs {
-					retryMs = c.max_wait_on_ratelimit_in_ms
-				}
-				time.Sleep(time.Duration(retryMs) * time.Millisecond)
-				fmt.Println("Retrying now...")
-				resp, body, err = c.makeReq(req)
-
-			} else {
-				fmt.Println("skipping waiting as its disabled now...")
-			}
-
-			if err != nil {
-				return nil, fmt.Errorf("invalid status code: %d, body: %s, Failed to parse retry time", resp.StatusCode, body)
-			}
-
-		}
+// CreateChatCompletionWithContext sends a request to create a chat completion,
+// honoring ctx for cancellation of both the HTTP call and any retry wait.
+// Retries are governed by the client's RetryPolicy (see WithRetryPolicy); by
+// default, 429/502/503/504 responses and transient network errors are
+// retried.
+func (c *client) CreateChatCompletionWithContext(ctx context.Context, req ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	resp, body, err := c.requestWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status code: %d, body: %s", resp.StatusCode, body)
 	}
 
 	var chatResp ChatCompletionResponse
@@ -189,6 +257,90 @@ func (c *client) CreateChatCompletion(req ChatCompletionRequest) (*ChatCompletio
 
 	return &chatResp, nil
 }
+
+// requestWithRetry performs req, retrying per c.retryPolicy while
+// c.wait_on_ratelimit is set.
+func (c *client) requestWithRetry(ctx context.Context, req ChatCompletionRequest) (*http.Response, []byte, error) {
+	return c.doWithRetry(ctx, func(attempt int) (*http.Response, []byte, error) {
+		return c.makeReq(ctx, req)
+	})
+}
+
+// doGet issues an authenticated GET request against the given path (relative
+// to baseURL) and returns the raw response and body.
+func (c *client) doGet(ctx context.Context, path string) (*http.Response, []byte, error) {
+	url := fmt.Sprintf("%s%s", c.baseURL, path)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read response body")
+	}
+	return resp, body, err
+}
+
+// ListModels lists the models currently available through the Groq API.
+//
+// Deprecated: prefer ListModelsWithContext.
+func (c *client) ListModels() (*ListModelsResponse, error) {
+	return c.ListModelsWithContext(context.Background())
+}
+
+// ListModelsWithContext lists the models currently available through the
+// Groq API, honoring ctx for cancellation.
+func (c *client) ListModelsWithContext(ctx context.Context) (*ListModelsResponse, error) {
+	resp, body, err := c.doGet(ctx, "/v1/models")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status code: %d, body: %s", resp.StatusCode, body)
+	}
+
+	var listResp ListModelsResponse
+	if err := json.Unmarshal(body, &listResp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response")
+	}
+	return &listResp, nil
+}
+
+// RetrieveModel retrieves details about a single model.
+//
+// Deprecated: prefer RetrieveModelWithContext.
+func (c *client) RetrieveModel(id ModelID) (*Model, error) {
+	return c.RetrieveModelWithContext(context.Background(), id)
+}
+
+// RetrieveModelWithContext retrieves details about a single model, honoring
+// ctx for cancellation.
+func (c *client) RetrieveModelWithContext(ctx context.Context, id ModelID) (*Model, error) {
+	resp, body, err := c.doGet(ctx, fmt.Sprintf("/v1/models/%s", id))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status code: %d, body: %s", resp.StatusCode, body)
+	}
+
+	var model Model
+	if err := json.Unmarshal(body, &model); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal response")
+	}
+	return &model, nil
+}
 func ExtractRetryTime(s string) (int, error) {
 	return extractRetryTime(s)
 }
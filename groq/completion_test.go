@@ -0,0 +1,100 @@
+package groq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fixedRetryPolicy retries retries times, waiting wait between each attempt,
+// regardless of the response seen.
+type fixedRetryPolicy struct {
+	wait    time.Duration
+	retries int
+}
+
+func (p *fixedRetryPolicy) ShouldRetry(resp *http.Response, body []byte, attempt int) (time.Duration, bool) {
+	if attempt >= p.retries {
+		return 0, false
+	}
+	return p.wait, true
+}
+
+func newTestClient(srv *httptest.Server, policy RetryPolicy) *client {
+	return &client{
+		apiKey:            "test-key",
+		baseURL:           srv.URL,
+		client:            srv.Client(),
+		wait_on_ratelimit: true,
+		retryPolicy:       policy,
+		logger:            noopLogger{},
+	}
+}
+
+func TestCreateChatCompletionWithContextCancelsDuringBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv, &fixedRetryPolicy{wait: 2 * time.Second, retries: 5})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := c.CreateChatCompletionWithContext(ctx, ChatCompletionRequest{})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("CreateChatCompletionWithContext took %v to return after cancellation, want well under the 2s backoff", elapsed)
+	}
+}
+
+func TestCreateChatCompletionWithContextRetriesThenSucceeds(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ChatCompletionResponse{
+			ID:    "chatcmpl-1",
+			Model: "llama3-8b-8192",
+			Choices: []Choice{{
+				Index:        0,
+				Message:      Message{Role: "assistant", Content: "hi"},
+				FinishReason: "stop",
+			}},
+		})
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv, &fixedRetryPolicy{wait: 10 * time.Millisecond, retries: 5})
+
+	resp, err := c.CreateChatCompletionWithContext(context.Background(), ChatCompletionRequest{
+		Model:    "llama3-8b-8192",
+		Messages: []Message{{Role: "user", Content: "hello"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateChatCompletionWithContext() error = %v", err)
+	}
+	if resp.Choices[0].Message.Content != "hi" {
+		t.Fatalf("response content = %q, want %q", resp.Choices[0].Message.Content, "hi")
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("server called %d times, want 2", got)
+	}
+}
@@ -0,0 +1,71 @@
+// Package jsonschema provides a minimal JSON Schema builder for describing
+// the parameters of a groq.FunctionDefinition without hand-building maps.
+package jsonschema
+
+import "encoding/json"
+
+// DataType is a JSON Schema primitive type.
+type DataType string
+
+const (
+	Object  DataType = "object"
+	Number  DataType = "number"
+	Integer DataType = "integer"
+	String  DataType = "string"
+	Array   DataType = "array"
+	Null    DataType = "null"
+	Boolean DataType = "boolean"
+)
+
+// Definition is a (subset of a) JSON Schema definition, sufficient to
+// describe the parameters object of a function tool.
+type Definition struct {
+	Type                 DataType              `json:"type,omitempty"`
+	Description          string                `json:"description,omitempty"`
+	Enum                 []string              `json:"enum,omitempty"`
+	Properties           map[string]Definition `json:"properties,omitempty"`
+	Required             []string              `json:"required,omitempty"`
+	Items                *Definition           `json:"items,omitempty"`
+	AdditionalProperties interface{}           `json:"additionalProperties,omitempty"`
+}
+
+// MarshalJSON ensures an Object definition always serializes its properties
+// as {} rather than omitting the field or emitting null, which some
+// providers reject; non-Object definitions (String, Integer, ...) have no
+// properties and omit the field entirely, as plain struct marshaling would.
+//
+// Properties is remapped to a pointer in the marshaled shape because
+// encoding/json's omitempty treats a non-nil, zero-length map the same as a
+// nil one; a pointer lets a present-but-empty map survive omitempty while a
+// genuinely absent one is still dropped.
+func (d Definition) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Type                 DataType               `json:"type,omitempty"`
+		Description          string                 `json:"description,omitempty"`
+		Enum                 []string               `json:"enum,omitempty"`
+		Properties           *map[string]Definition `json:"properties,omitempty"`
+		Required             []string               `json:"required,omitempty"`
+		Items                *Definition            `json:"items,omitempty"`
+		AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	}
+
+	a := alias{
+		Type:                 d.Type,
+		Description:          d.Description,
+		Enum:                 d.Enum,
+		Required:             d.Required,
+		Items:                d.Items,
+		AdditionalProperties: d.AdditionalProperties,
+	}
+	switch {
+	case d.Type == Object:
+		props := d.Properties
+		if props == nil {
+			props = map[string]Definition{}
+		}
+		a.Properties = &props
+	case d.Properties != nil:
+		a.Properties = &d.Properties
+	}
+	return json.Marshal(a)
+}
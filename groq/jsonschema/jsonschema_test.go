@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDefinitionMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		def  Definition
+		want string
+	}{
+		{
+			"object with nil properties defaults to {}",
+			Definition{Type: Object},
+			`{"type":"object","properties":{}}`,
+		},
+		{
+			"object with explicit properties preserved",
+			Definition{Type: Object, Properties: map[string]Definition{
+				"name": {Type: String},
+			}},
+			`{"type":"object","properties":{"name":{"type":"string"}}}`,
+		},
+		{
+			"leaf string schema has no properties key",
+			Definition{Type: String, Description: "the city name"},
+			`{"type":"string","description":"the city name"}`,
+		},
+		{
+			"leaf integer schema has no properties key",
+			Definition{Type: Integer},
+			`{"type":"integer"}`,
+		},
+		{
+			"array schema has no properties key",
+			Definition{Type: Array, Items: &Definition{Type: String}},
+			`{"type":"array","items":{"type":"string"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.def)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
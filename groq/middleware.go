@@ -0,0 +1,164 @@
+package groq
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+	"time"
+)
+
+// RoundTripper is an alias for http.RoundTripper, named locally so
+// RequestMiddleware signatures read without an http. prefix.
+type RoundTripper = http.RoundTripper
+
+// RoundTripperFunc adapts a function to a RoundTripper, mirroring
+// http.HandlerFunc.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RequestMiddleware wraps a RoundTripper with additional behavior, e.g.
+// logging, metrics, or request signing. Middlewares are applied in the order
+// passed to WithRequestMiddleware, so the first middleware sees the request
+// first and the response last.
+type RequestMiddleware func(next RoundTripper) RoundTripper
+
+// Hooks are lifecycle callbacks invoked around each HTTP request the client
+// makes. All fields are optional.
+type Hooks struct {
+	// OnRequest is called immediately before a request is sent.
+	OnRequest func(*http.Request)
+	// OnResponse is called after a response is received (or the attempt
+	// failed, in which case resp is nil), with the round-trip latency.
+	OnResponse func(req *http.Request, resp *http.Response, latency time.Duration)
+	// OnRetry is called before waiting to retry a request.
+	OnRetry func(attempt int, wait time.Duration)
+	// OnRateLimit is called before waiting specifically on a 429 response.
+	OnRateLimit func(wait time.Duration)
+}
+
+// WithRequestMiddleware appends to the chain of RequestMiddleware wrapping
+// the client's transport.
+func WithRequestMiddleware(mw ...RequestMiddleware) ClientOption {
+	return func(c *client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// WithHooks sets the lifecycle callbacks invoked around each HTTP request.
+func WithHooks(hooks Hooks) ClientOption {
+	return func(c *client) {
+		c.hooks = hooks
+	}
+}
+
+// hookRoundTripper invokes Hooks.OnRequest/OnResponse around next.
+type hookRoundTripper struct {
+	next  RoundTripper
+	hooks Hooks
+}
+
+func (h hookRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if h.hooks.OnRequest != nil {
+		h.hooks.OnRequest(req)
+	}
+
+	start := time.Now()
+	resp, err := h.next.RoundTrip(req)
+
+	if h.hooks.OnResponse != nil {
+		h.hooks.OnResponse(req, resp, time.Since(start))
+	}
+	return resp, err
+}
+
+// buildTransport wraps base with c.middlewares (outermost-first) and a
+// hookRoundTripper reporting c.hooks.
+func (c *client) buildTransport(base RoundTripper) RoundTripper {
+	rt := RoundTripper(hookRoundTripper{next: base, hooks: c.hooks})
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		rt = c.middlewares[i](rt)
+	}
+	return rt
+}
+
+// MetricsSink receives per-request token usage and latency, keyed by model.
+// Implement it to feed a metrics backend; the default NoopMetricsSink
+// discards everything.
+type MetricsSink interface {
+	ObserveRequest(model string, usage Usage, latency time.Duration)
+}
+
+// NoopMetricsSink is a MetricsSink that discards everything.
+type NoopMetricsSink struct{}
+
+func (NoopMetricsSink) ObserveRequest(string, Usage, time.Duration) {}
+
+// MetricsMiddleware records prompt/completion token usage and latency for
+// successful, non-streaming chat completion responses into sink. Streaming
+// and non-chat-completion requests are passed through untouched: buffering a
+// streaming response's body here would block on the full SSE stream (or
+// hang forever on one that never terminates) instead of letting the caller
+// read it incrementally.
+func MetricsMiddleware(sink MetricsSink) RequestMiddleware {
+	return func(next RoundTripper) RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			streaming := req.Header.Get("Accept") == "text/event-stream"
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil || resp == nil || resp.StatusCode != http.StatusOK {
+				return resp, err
+			}
+			if streaming || !isChatCompletionsPath(req.URL.Path) {
+				return resp, err
+			}
+
+			body, readErr := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			if readErr != nil {
+				return resp, err
+			}
+
+			var parsed struct {
+				Model string `json:"model"`
+				Usage Usage  `json:"usage"`
+			}
+			if json.Unmarshal(body, &parsed) == nil {
+				sink.ObserveRequest(parsed.Model, parsed.Usage, time.Since(start))
+			}
+
+			return resp, err
+		})
+	}
+}
+
+func isChatCompletionsPath(path string) bool {
+	const suffix = "/chat/completions"
+	return len(path) >= len(suffix) && path[len(path)-len(suffix):] == suffix
+}
+
+// authorizationHeaderRe matches an entire "Authorization: ..." header line so
+// it can be replaced wholesale; a value like "Bearer sk-..." contains
+// whitespace, so a pattern that only consumes one token would leave the key
+// in cleartext after the first space.
+var authorizationHeaderRe = regexp.MustCompile(`(?im)^Authorization:.*$`)
+
+// DumpingMiddleware logs a header-only dump of each outgoing request through
+// logger, with the Authorization header's value redacted.
+func DumpingMiddleware(logger Logger) RequestMiddleware {
+	return func(next RoundTripper) RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if dump, err := httputil.DumpRequestOut(req, false); err == nil {
+				logger.Printf("groq: request:\n%s", authorizationHeaderRe.ReplaceAll(dump, []byte("Authorization: [REDACTED]")))
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package groq
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthorizationHeaderRedactsWholeValue(t *testing.T) {
+	dump := []byte("POST /v1/chat/completions HTTP/1.1\r\n" +
+		"Host: api.groq.com\r\n" +
+		"Authorization: Bearer sk-abcdef1234567890\r\n" +
+		"Content-Type: application/json\r\n")
+
+	got := string(authorizationHeaderRe.ReplaceAll(dump, []byte("Authorization: [REDACTED]")))
+
+	if strings.Contains(got, "sk-abcdef1234567890") {
+		t.Fatalf("redacted dump still contains the secret:\n%s", got)
+	}
+	if !strings.Contains(got, "Authorization: [REDACTED]") {
+		t.Fatalf("redacted dump missing replacement marker:\n%s", got)
+	}
+}
+
+type fakeLogger struct{ lines []string }
+
+func (f *fakeLogger) Printf(format string, args ...interface{}) {
+	f.lines = append(f.lines, fmt.Sprintf(format, args...))
+}
+
+func TestDumpingMiddlewareRedactsAuthorization(t *testing.T) {
+	logger := &fakeLogger{}
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(nil)), Header: http.Header{}}, nil
+	})
+	rt := DumpingMiddleware(logger)(next)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.groq.com/openai/v1/chat/completions", bytes.NewBufferString(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer sk-realsecrettoken")
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if len(logger.lines) != 1 {
+		t.Fatalf("expected 1 logged line, got %d", len(logger.lines))
+	}
+	if strings.Contains(logger.lines[0], "sk-realsecrettoken") {
+		t.Fatalf("logged dump leaked the API key: %s", logger.lines[0])
+	}
+}
+
+func TestMetricsMiddlewarePassesThroughStreamingRequests(t *testing.T) {
+	bodyReadCount := 0
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(&countingReader{r: bytes.NewBufferString(`{"model":"x","usage":{}}`), reads: &bodyReadCount}),
+			Header:     http.Header{},
+		}, nil
+	})
+
+	sink := &recordingSink{}
+	rt := MetricsMiddleware(sink)(next)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.groq.com/openai/v1/chat/completions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if bodyReadCount != 0 {
+		t.Fatalf("streaming response body was read %d times, want 0 (middleware must not buffer it)", bodyReadCount)
+	}
+	if sink.calls != 0 {
+		t.Fatalf("sink.ObserveRequest called %d times for a streaming request, want 0", sink.calls)
+	}
+	_ = resp
+}
+
+func TestMetricsMiddlewareRecordsNonStreamingRequests(t *testing.T) {
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"model":"llama3","usage":{"total_tokens":42}}`)),
+			Header:     http.Header{},
+		}, nil
+	})
+
+	sink := &recordingSink{}
+	rt := MetricsMiddleware(sink)(next)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.groq.com/openai/v1/chat/completions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	if sink.calls != 1 {
+		t.Fatalf("sink.ObserveRequest called %d times, want 1", sink.calls)
+	}
+	if sink.model != "llama3" {
+		t.Fatalf("sink.model = %q, want %q", sink.model, "llama3")
+	}
+}
+
+type countingReader struct {
+	r     io.Reader
+	reads *int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	*c.reads++
+	return c.r.Read(p)
+}
+
+type recordingSink struct {
+	calls int
+	model string
+}
+
+func (s *recordingSink) ObserveRequest(model string, usage Usage, latency time.Duration) {
+	s.calls++
+	s.model = model
+}
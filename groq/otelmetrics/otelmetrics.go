@@ -0,0 +1,67 @@
+// Package otelmetrics adapts groq.MetricsSink to OpenTelemetry metrics, for
+// callers who already export metrics via an OTel MeterProvider.
+package otelmetrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/chotu-news/groq-go/groq"
+)
+
+var _ groq.MetricsSink = (*Sink)(nil)
+
+// Sink is a groq.MetricsSink that records token usage and latency as
+// OpenTelemetry instruments.
+type Sink struct {
+	promptTokens     metric.Int64Counter
+	completionTokens metric.Int64Counter
+	latency          metric.Float64Histogram
+}
+
+// New creates a Sink backed by instruments registered on meter.
+func New(meter metric.Meter) (*Sink, error) {
+	promptTokens, err := meter.Int64Counter(
+		"groq.chat.prompt_tokens",
+		metric.WithDescription("Prompt tokens consumed per chat completion request"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	completionTokens, err := meter.Int64Counter(
+		"groq.chat.completion_tokens",
+		metric.WithDescription("Completion tokens generated per chat completion request"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	latency, err := meter.Float64Histogram(
+		"groq.chat.request_latency",
+		metric.WithDescription("Chat completion request latency"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Sink{
+		promptTokens:     promptTokens,
+		completionTokens: completionTokens,
+		latency:          latency,
+	}, nil
+}
+
+// ObserveRequest implements groq.MetricsSink.
+func (s *Sink) ObserveRequest(model string, usage groq.Usage, latency time.Duration) {
+	ctx := context.Background()
+	attrs := metric.WithAttributes(attribute.String("model", model))
+
+	s.promptTokens.Add(ctx, int64(usage.PromptTokens), attrs)
+	s.completionTokens.Add(ctx, int64(usage.CompletionTokens), attrs)
+	s.latency.Record(ctx, latency.Seconds(), attrs)
+}
@@ -0,0 +1,210 @@
+package groq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Logger is the logging hook used by the client to report retry and
+// rate-limit activity. Implement it to route log lines into your own
+// logging stack; NewClient uses a no-op Logger unless WithLogger is given.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Printf(string, ...interface{}) {}
+
+// stdLogger adapts the standard library's log package to the Logger
+// interface.
+type stdLogger struct {
+	logf func(format string, args ...interface{})
+}
+
+func (l stdLogger) Printf(format string, args ...interface{}) {
+	l.logf(format, args...)
+}
+
+// NewStdLogger returns a Logger that writes through logf, e.g. log.Printf.
+func NewStdLogger(logf func(format string, args ...interface{})) Logger {
+	return stdLogger{logf: logf}
+}
+
+// RetryPolicy decides whether a request should be retried after receiving
+// resp/body (the response and body read from a completed request, nil if the
+// request failed before a response was received) and, if so, how long to
+// wait before the next attempt. attempt is the 0-based index of the attempt
+// that just completed (0 on the first retry decision).
+type RetryPolicy interface {
+	ShouldRetry(resp *http.Response, body []byte, attempt int) (wait time.Duration, retry bool)
+}
+
+// defaultRetryPolicy retries 429/502/503/504 responses, honoring Retry-After
+// (seconds or HTTP-date) and falling back to the retry time embedded in the
+// error message (e.g. "Please try again in 250ms."), then exponential
+// backoff with jitter. maxWait caps any computed wait.
+type defaultRetryPolicy struct {
+	maxRetries  int
+	maxWait     time.Duration
+	baseBackoff time.Duration
+}
+
+// NewDefaultRetryPolicy returns the RetryPolicy used by NewClient when none
+// is supplied via WithRetryPolicy. maxWait of 0 means no ceiling is applied.
+func NewDefaultRetryPolicy(maxRetries int, maxWait time.Duration) RetryPolicy {
+	return &defaultRetryPolicy{
+		maxRetries:  maxRetries,
+		maxWait:     maxWait,
+		baseBackoff: 250 * time.Millisecond,
+	}
+}
+
+func (p *defaultRetryPolicy) ShouldRetry(resp *http.Response, body []byte, attempt int) (time.Duration, bool) {
+	if attempt >= p.maxRetries {
+		return 0, false
+	}
+
+	if resp == nil {
+		// No response was received at all (e.g. a transient network error);
+		// back off and try again.
+		return p.clamp(p.backoff(attempt)), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return 0, false
+	}
+
+	if wait, ok := retryAfterFromHeader(resp.Header.Get("Retry-After")); ok {
+		return p.clamp(wait), true
+	}
+
+	if wait, ok := retryAfterFromBody(body); ok {
+		return p.clamp(wait), true
+	}
+
+	return p.clamp(p.backoff(attempt)), true
+}
+
+func (p *defaultRetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.baseBackoff << attempt
+	jitter := time.Duration(rand.Int63n(int64(p.baseBackoff) + 1))
+	return backoff + jitter
+}
+
+func (p *defaultRetryPolicy) clamp(wait time.Duration) time.Duration {
+	if p.maxWait > 0 && wait > p.maxWait {
+		return p.maxWait
+	}
+	return wait
+}
+
+// retryAfterFromHeader parses a Retry-After header value in either the
+// delay-seconds or HTTP-date form.
+func retryAfterFromHeader(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(when); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// retryAfterFromBody looks for a "Please try again in <n><unit>." message
+// embedded in the response body's error.message field.
+func retryAfterFromBody(body []byte) (time.Duration, bool) {
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return 0, false
+	}
+
+	retryMs, err := extractRetryTime(errResp.Error.Message)
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(retryMs) * time.Millisecond, true
+}
+
+// doWithRetry runs do, which should perform one full request/response round
+// trip for the given 0-based attempt number, retrying per c.retryPolicy
+// while c.wait_on_ratelimit is set. It's shared by the chat completion and
+// audio request paths so their retry/backoff/hook behavior can't drift
+// apart.
+func (c *client) doWithRetry(ctx context.Context, do func(attempt int) (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		resp, body, err := do(attempt)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			return resp, body, nil
+		}
+
+		if !c.wait_on_ratelimit {
+			return resp, body, err
+		}
+		if err != nil && !isTransientNetError(err) {
+			return resp, body, err
+		}
+
+		wait, retry := c.retryPolicy.ShouldRetry(resp, body, attempt)
+		if !retry {
+			return resp, body, err
+		}
+
+		c.logger.Printf("groq: retrying request after %s (attempt %d)", wait, attempt+1)
+		if c.hooks.OnRetry != nil {
+			c.hooks.OnRetry(attempt+1, wait)
+		}
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests && c.hooks.OnRateLimit != nil {
+			c.hooks.OnRateLimit(wait)
+		}
+		if waitErr := waitCtx(ctx, wait); waitErr != nil {
+			return nil, nil, waitErr
+		}
+	}
+}
+
+// waitCtx blocks for d, returning early with ctx's error if ctx is done first.
+func waitCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTransientNetError reports whether err looks like a retryable,
+// connection-level failure rather than a permanent one. err is typically a
+// fmt.Errorf-wrapped net.Error (e.g. "failed to send request: %w"), so the
+// underlying error is located with errors.As rather than a direct type
+// assertion.
+func isTransientNetError(err error) bool {
+	type temporary interface {
+		Temporary() bool
+	}
+	var t temporary
+	if errors.As(err, &t) {
+		return t.Temporary()
+	}
+	return false
+}
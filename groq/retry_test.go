@@ -0,0 +1,148 @@
+package groq
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterFromHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "5", 5 * time.Second, true},
+		{"zero seconds", "0", 0, true},
+		{"future http-date", time.Now().Add(2 * time.Minute).UTC().Format(http.TimeFormat), 2 * time.Minute, true},
+		{"past http-date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+		{"garbage", "not-a-date-or-seconds", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := retryAfterFromHeader(tt.value)
+			if ok != tt.wantOK {
+				t.Fatalf("retryAfterFromHeader(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			// http-date parsing is lossy to the second; allow a small delta.
+			delta := wait - tt.wantWait
+			if delta < -time.Second || delta > time.Second {
+				t.Fatalf("retryAfterFromHeader(%q) wait = %v, want ~%v", tt.value, wait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestRetryAfterFromBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		{
+			"embedded ms",
+			`{"error":{"message":"Rate limit reached. Please try again in 250ms.","type":"rate_limit_error"}}`,
+			250 * time.Millisecond,
+			true,
+		},
+		{
+			"embedded seconds",
+			`{"error":{"message":"Please try again in 3s.","type":"rate_limit_error"}}`,
+			3 * time.Second,
+			true,
+		},
+		{"no match", `{"error":{"message":"invalid request","type":"invalid_request_error"}}`, 0, false},
+		{"not json", "not json at all", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := retryAfterFromBody([]byte(tt.body))
+			if ok != tt.wantOK {
+				t.Fatalf("retryAfterFromBody(%q) ok = %v, want %v", tt.body, ok, tt.wantOK)
+			}
+			if ok && wait != tt.wantWait {
+				t.Fatalf("retryAfterFromBody(%q) wait = %v, want %v", tt.body, wait, tt.wantWait)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryPolicyBackoffDoublesAndClampsToMaxWait(t *testing.T) {
+	p := NewDefaultRetryPolicy(5, 2*time.Second).(*defaultRetryPolicy)
+
+	for attempt := 0; attempt < 5; attempt++ {
+		wait, retry := p.ShouldRetry(nil, nil, attempt)
+		if !retry {
+			t.Fatalf("attempt %d: ShouldRetry = false, want true", attempt)
+		}
+		if wait > p.maxWait {
+			t.Fatalf("attempt %d: wait %v exceeds maxWait %v", attempt, wait, p.maxWait)
+		}
+		floor := p.baseBackoff << attempt
+		if floor > p.maxWait {
+			floor = p.maxWait
+		}
+		if wait < floor {
+			t.Fatalf("attempt %d: wait %v below doubled backoff %v", attempt, wait, floor)
+		}
+	}
+}
+
+func TestDefaultRetryPolicyStopsAtMaxRetries(t *testing.T) {
+	p := NewDefaultRetryPolicy(2, 0)
+
+	if _, retry := p.ShouldRetry(nil, nil, 2); retry {
+		t.Fatalf("ShouldRetry at attempt == maxRetries = true, want false")
+	}
+}
+
+func TestDefaultRetryPolicyOnlyRetriesKnownStatusCodes(t *testing.T) {
+	p := NewDefaultRetryPolicy(3, 0)
+
+	for _, code := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		if _, retry := p.ShouldRetry(&http.Response{StatusCode: code, Header: http.Header{}}, nil, 0); !retry {
+			t.Fatalf("status %d: ShouldRetry = false, want true", code)
+		}
+	}
+
+	if _, retry := p.ShouldRetry(&http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}, nil, 0); retry {
+		t.Fatalf("status 400: ShouldRetry = true, want false")
+	}
+}
+
+type temporaryErr struct{ temporary bool }
+
+func (e temporaryErr) Error() string   { return "temporary error" }
+func (e temporaryErr) Temporary() bool { return e.temporary }
+
+func TestIsTransientNetErrorUnwraps(t *testing.T) {
+	wrapped := errors.New("some unrelated error")
+	if isTransientNetError(wrapped) {
+		t.Fatalf("isTransientNetError(%v) = true, want false", wrapped)
+	}
+
+	transient := temporaryErr{temporary: true}
+	if !isTransientNetError(transient) {
+		t.Fatalf("isTransientNetError(%v) = false, want true", transient)
+	}
+
+	wrappedTransient := fmt.Errorf("failed to send request: %w", transient)
+	if !isTransientNetError(wrappedTransient) {
+		t.Fatalf("isTransientNetError(%v) = false, want true", wrappedTransient)
+	}
+
+	permanent := temporaryErr{temporary: false}
+	if isTransientNetError(permanent) {
+		t.Fatalf("isTransientNetError(%v) = true, want false", permanent)
+	}
+}
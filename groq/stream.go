@@ -0,0 +1,129 @@
+package groq
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StreamOptions controls behavior specific to streaming chat completions.
+type StreamOptions struct {
+	// IncludeUsage, when true, causes an extra chunk to be streamed before
+	// the final "[DONE]" message. This chunk's Usage field reports the token
+	// usage for the entire request, and its Choices field is empty.
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
+// ChatCompletionStreamResponse represents a single chunk of a streamed chat
+// completion. When StreamOptions.IncludeUsage is set on the request, the
+// terminal chunk carries a non-nil Usage and an empty Choices slice.
+type ChatCompletionStreamResponse struct {
+	ID                string   `json:"id"`                 // Unique identifier for the completion
+	Object            string   `json:"object"`             // Type of the object (e.g., "chat.completion.chunk")
+	Created           int64    `json:"created"`            // Timestamp of creation
+	Model             string   `json:"model"`              // ID of the model used
+	SystemFingerprint string   `json:"system_fingerprint"` // System fingerprint
+	Choices           []Choice `json:"choices"`            // List of completion choice deltas
+	Usage             *Usage   `json:"usage"`              // Token usage information; only set on the terminal chunk
+}
+
+const streamDoneMarker = "[DONE]"
+
+// sseData extracts the payload of an SSE "data:" line. It returns ok=false
+// for blank lines and any other SSE field the Groq API doesn't send
+// (comments, "event:", "id:", etc.), which callers should skip.
+func sseData(line string) (data string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || !strings.HasPrefix(line, "data:") {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, "data:")), true
+}
+
+// CreateChatCompletionStream opens a streaming chat completion request. The
+// returned data channel is sent one *ChatCompletionStreamResponse per SSE
+// chunk and is closed when the stream ends, whether that's a normal
+// "[DONE]" marker, cancellation, or an error. If the stream ended because of
+// an error (a malformed chunk, or the underlying read failing before
+// "[DONE]" was seen, e.g. a dropped connection or a line past
+// bufio.Scanner's token limit), it is sent on the returned error channel
+// before both channels are closed; a clean end-of-stream sends nothing on
+// it. The returned func cancels the request and must be called to release
+// the underlying connection once the caller is done reading.
+func (c *client) CreateChatCompletionStream(ctx context.Context, req ChatCompletionRequest) (<-chan *ChatCompletionStreamResponse, <-chan error, func(), error) {
+	req.Stream = true
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	url := fmt.Sprintf("%s/v1/chat/completions", c.baseURL)
+
+	jsonData, err := json.Marshal(req)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, fmt.Errorf("failed to send request: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		cancel()
+		return nil, nil, nil, fmt.Errorf("invalid status code: %d, body: %s", resp.StatusCode, body)
+	}
+
+	stream := make(chan *ChatCompletionStreamResponse)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(stream)
+		defer func() { _ = resp.Body.Close() }()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			data, ok := sseData(scanner.Text())
+			if !ok {
+				continue
+			}
+			if data == streamDoneMarker {
+				return
+			}
+
+			var chunk ChatCompletionStreamResponse
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				errs <- fmt.Errorf("failed to decode stream chunk: %w", err)
+				return
+			}
+
+			select {
+			case stream <- &chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			errs <- fmt.Errorf("failed to read stream: %w", err)
+		}
+	}()
+
+	return stream, errs, cancel, nil
+}
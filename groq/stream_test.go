@@ -0,0 +1,32 @@
+package groq
+
+import "testing"
+
+func TestSSEData(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantData string
+		wantOK   bool
+	}{
+		{"data line", "data: {\"id\":\"1\"}", `{"id":"1"}`, true},
+		{"data line no space", "data:{\"id\":\"1\"}", `{"id":"1"}`, true},
+		{"done marker", "data: [DONE]", streamDoneMarker, true},
+		{"blank line", "", "", false},
+		{"whitespace only", "   ", "", false},
+		{"comment", ": keep-alive", "", false},
+		{"event field", "event: message", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, ok := sseData(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("sseData(%q) ok = %v, want %v", tt.line, ok, tt.wantOK)
+			}
+			if data != tt.wantData {
+				t.Fatalf("sseData(%q) data = %q, want %q", tt.line, data, tt.wantData)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package groq
+
+import (
+	"encoding/json"
+
+	"github.com/chotu-news/groq-go/groq/jsonschema"
+)
+
+// Tool describes a function the model may call.
+type Tool struct {
+	Type     string             `json:"type"` // Currently only "function" is supported
+	Function FunctionDefinition `json:"function"`
+}
+
+// FunctionDefinition describes a callable function's name, description, and
+// JSON Schema parameters.
+type FunctionDefinition struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  *jsonschema.Definition `json:"parameters,omitempty"`
+}
+
+// FunctionCall is a single function invocation, either requested by the
+// model as part of a ToolCall or, historically, the response to the
+// deprecated top-level function_call request field.
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"` // JSON-encoded arguments, as produced by the model
+}
+
+// ToolCall is a single tool invocation requested by the model.
+type ToolCall struct {
+	ID       string       `json:"id"`
+	Type     string       `json:"type"` // Currently only "function" is supported
+	Function FunctionCall `json:"function"`
+}
+
+// ToolChoice controls which, if any, tool the model must call. The zero
+// value lets the API apply its own default. Use ToolChoiceAuto, ToolChoiceNone,
+// or ToolChoiceRequired for the string variants, or ToolChoiceFunction to
+// force a specific function.
+type ToolChoice struct {
+	mode     string
+	function string
+}
+
+var (
+	ToolChoiceAuto     = ToolChoice{mode: "auto"}
+	ToolChoiceNone     = ToolChoice{mode: "none"}
+	ToolChoiceRequired = ToolChoice{mode: "required"}
+)
+
+// ToolChoiceFunction forces the model to call the named function.
+func ToolChoiceFunction(name string) ToolChoice {
+	return ToolChoice{function: name}
+}
+
+// MarshalJSON renders the bare mode string ("auto"/"none"/"required") or, for
+// a forced function, {"type": "function", "function": {"name": "..."}}.
+func (t ToolChoice) MarshalJSON() ([]byte, error) {
+	if t.function != "" {
+		return json.Marshal(struct {
+			Type     string `json:"type"`
+			Function struct {
+				Name string `json:"name"`
+			} `json:"function"`
+		}{
+			Type: "function",
+			Function: struct {
+				Name string `json:"name"`
+			}{Name: t.function},
+		})
+	}
+	if t.mode == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.mode)
+}
+
+// FunctionCallOption controls the deprecated top-level function_call
+// request field. The zero value lets the API apply its own default. Use
+// FunctionCallAuto or FunctionCallNone for the string variants, or
+// FunctionCallNamed to force a specific function.
+//
+// Deprecated: superseded by ToolChoice. Unlike ToolChoice, function_call's
+// wire format for a forced function is the bare {"name": "..."}, not
+// {"type": "function", "function": {...}}, so it is not a ToolChoice alias.
+type FunctionCallOption struct {
+	mode string
+	name string
+}
+
+var (
+	FunctionCallAuto = FunctionCallOption{mode: "auto"}
+	FunctionCallNone = FunctionCallOption{mode: "none"}
+)
+
+// FunctionCallNamed forces the model to call the named function.
+func FunctionCallNamed(name string) FunctionCallOption {
+	return FunctionCallOption{name: name}
+}
+
+// MarshalJSON renders the bare mode string ("auto"/"none") or, for a forced
+// function, {"name": "..."}.
+func (f FunctionCallOption) MarshalJSON() ([]byte, error) {
+	if f.name != "" {
+		return json.Marshal(struct {
+			Name string `json:"name"`
+		}{Name: f.name})
+	}
+	if f.mode == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.mode)
+}
+
+// ResponseFormat constrains the shape of the model's output.
+type ResponseFormat struct {
+	Type       string                    `json:"type"` // "text" (default), "json_object", or "json_schema"
+	JSONSchema *ResponseFormatJSONSchema `json:"json_schema,omitempty"`
+}
+
+// ResponseFormatJSONSchema describes the schema enforced when
+// ResponseFormat.Type is "json_schema".
+type ResponseFormatJSONSchema struct {
+	Name   string                 `json:"name"`
+	Strict bool                   `json:"strict,omitempty"`
+	Schema *jsonschema.Definition `json:"schema,omitempty"`
+}
@@ -0,0 +1,77 @@
+package groq
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToolChoiceMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		tc   ToolChoice
+		want string
+	}{
+		{"zero value", ToolChoice{}, "null"},
+		{"auto", ToolChoiceAuto, `"auto"`},
+		{"none", ToolChoiceNone, `"none"`},
+		{"required", ToolChoiceRequired, `"required"`},
+		{"forced function", ToolChoiceFunction("get_weather"), `{"type":"function","function":{"name":"get_weather"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.tc)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFunctionCallOptionMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		fc   FunctionCallOption
+		want string
+	}{
+		{"zero value", FunctionCallOption{}, "null"},
+		{"auto", FunctionCallAuto, `"auto"`},
+		{"none", FunctionCallNone, `"none"`},
+		{"named function", FunctionCallNamed("get_weather"), `{"name":"get_weather"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.fc)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseFormatMarshalJSON(t *testing.T) {
+	rf := ResponseFormat{
+		Type: "json_schema",
+		JSONSchema: &ResponseFormatJSONSchema{
+			Name:   "weather",
+			Strict: true,
+		},
+	}
+
+	got, err := json.Marshal(rf)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := `{"type":"json_schema","json_schema":{"name":"weather","strict":true}}`
+	if string(got) != want {
+		t.Fatalf("Marshal() = %s, want %s", got, want)
+	}
+}